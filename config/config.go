@@ -0,0 +1,230 @@
+// Package config holds the site2rss YAML configuration and how to load it.
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+type Config struct {
+	// Stylesheet is a path or URL to an XSL file prepended as an
+	// xml-stylesheet processing instruction to every RSS/Atom feed, so
+	// browsers render the feed instead of showing raw XML.
+	Stylesheet string `yaml:"stylesheet"`
+	Sites      []Site `yaml:"sites"`
+}
+
+type Site struct {
+	Name        string   `yaml:"name"`
+	Title       string   `yaml:"title"`
+	Description string   `yaml:"description"`
+	URL         string   `yaml:"url"`
+	Selector    Selector `yaml:"selector"`
+	Enrich      *Enrich  `yaml:"enrich"`
+	// TagDomain and TagStartDate build a stable tag: URI (RFC 4151) for
+	// each item's feed id, so readers keep deduping entries even if Link
+	// changes. Both must be set for tag ids to be used.
+	TagDomain    string `yaml:"tag_domain"`
+	TagStartDate string `yaml:"tag_start_date"` // YYYY-MM-DD
+	// Interval overrides the global update interval for this site alone.
+	// Zero falls back to the scheduler's default interval.
+	Interval time.Duration `yaml:"interval"`
+	Filters  *Filters      `yaml:"filters"`
+
+	// Render selects the fetch backend. Empty or "http" fetches the page
+	// as-is; "chromium" renders it in headless Chromium first, for sites
+	// that hydrate their content client-side.
+	Render RenderBackend `yaml:"render"`
+	// RenderSelector is a CSS selector the chromium backend waits to
+	// become visible before capturing the page. Empty waits a fixed grace
+	// period instead.
+	RenderSelector string `yaml:"render_selector"`
+	// RenderTimeout bounds how long the chromium backend waits for a page
+	// to render. Zero falls back to a default of 30s.
+	RenderTimeout time.Duration `yaml:"render_timeout"`
+}
+
+// Equal reports whether s and other describe the same site configuration.
+// It compares Enrich and Filters by value rather than pointer identity, so a
+// config reload that reparses the YAML into fresh pointers doesn't count as
+// a change when the underlying settings are unchanged.
+func (s Site) Equal(other Site) bool {
+	if s.Name != other.Name ||
+		s.Title != other.Title ||
+		s.Description != other.Description ||
+		s.URL != other.URL ||
+		s.Selector != other.Selector ||
+		s.TagDomain != other.TagDomain ||
+		s.TagStartDate != other.TagStartDate ||
+		s.Interval != other.Interval ||
+		s.Render != other.Render ||
+		s.RenderSelector != other.RenderSelector ||
+		s.RenderTimeout != other.RenderTimeout {
+		return false
+	}
+
+	if !enrichEqual(s.Enrich, other.Enrich) {
+		return false
+	}
+
+	return filtersEqual(s.Filters, other.Filters)
+}
+
+func enrichEqual(a, b *Enrich) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func filtersEqual(a, b *Filters) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return slices.Equal(a.Include, b.Include) &&
+		slices.Equal(a.Exclude, b.Exclude) &&
+		a.MinAge == b.MinAge &&
+		a.MaxAge == b.MaxAge
+}
+
+// RenderBackend selects how a site's page is fetched before scraping.
+type RenderBackend string
+
+const (
+	RenderHTTP     RenderBackend = "http"
+	RenderChromium RenderBackend = "chromium"
+)
+
+// Filters drops scraped items that don't belong in the feed. Include and
+// Exclude are regexes matched against an item's Title, Description and
+// Link; an item is kept only if it matches no Exclude pattern and, when
+// Include is non-empty, at least one Include pattern. MinAge/MaxAge bound
+// how long ago AddedAt must be for the item to stay in the feed.
+type Filters struct {
+	Include []string      `yaml:"include"`
+	Exclude []string      `yaml:"exclude"`
+	MinAge  time.Duration `yaml:"min_age"`
+	MaxAge  time.Duration `yaml:"max_age"`
+
+	includeRe []*regexp.Regexp
+	excludeRe []*regexp.Regexp
+}
+
+// Compile compiles the Include/Exclude regex patterns. Load calls this
+// automatically; callers constructing a Filters by hand must call it before
+// using Reason.
+func (f *Filters) Compile() error {
+	for _, pattern := range f.Include {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("compile include filter %q: %w", pattern, err)
+		}
+		f.includeRe = append(f.includeRe, re)
+	}
+
+	for _, pattern := range f.Exclude {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("compile exclude filter %q: %w", pattern, err)
+		}
+		f.excludeRe = append(f.excludeRe, re)
+	}
+
+	return nil
+}
+
+// Reason reports why an item with the given fields should be dropped from
+// the feed, or "" if it passes every configured filter.
+func (f *Filters) Reason(title, description, link string, age time.Duration) string {
+	if reason := f.CacheReason(title, description, link, age); reason != "" {
+		return reason
+	}
+
+	if f.MinAge > 0 && age < f.MinAge {
+		return "min_age"
+	}
+
+	return ""
+}
+
+// CacheReason reports why an item with the given fields should be dropped
+// from the cache entirely, or "" if it should be kept. Unlike Reason, it
+// ignores MinAge: a brand-new item is always too young to clear MinAge, so
+// excluding it here would mean it's never cached, its AddedAt never stops
+// resetting to now, and it can never age into the feed. Keeping it cached
+// lets it reappear in the feed once it's old enough.
+func (f *Filters) CacheReason(title, description, link string, age time.Duration) string {
+	for _, re := range f.excludeRe {
+		if re.MatchString(title) || re.MatchString(description) || re.MatchString(link) {
+			return "exclude"
+		}
+	}
+
+	if len(f.includeRe) > 0 {
+		matched := false
+		for _, re := range f.includeRe {
+			if re.MatchString(title) || re.MatchString(description) || re.MatchString(link) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return "include"
+		}
+	}
+
+	if f.MaxAge > 0 && age > f.MaxAge {
+		return "max_age"
+	}
+
+	return ""
+}
+
+type Selector struct {
+	Item        string `yaml:"item"`
+	Link        string `yaml:"link"`
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+}
+
+// Enrich configures a second fetch of each item's Link to scrape the full
+// article content from its detail page.
+type Enrich struct {
+	ContentSelector   string        `yaml:"content_selector"`
+	AuthorSelector    string        `yaml:"author_selector"`
+	PublishedSelector string        `yaml:"published_selector"`
+	MaxDepth          int           `yaml:"max_depth"`   // 0 means enrich every item
+	Concurrency       int           `yaml:"concurrency"` // 0 falls back to 1
+	RateLimit         time.Duration `yaml:"rate_limit"`  // minimum delay between requests to the same host
+}
+
+// Load reads and parses the YAML config file at path.
+func Load(path string) (Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("read config: %w", err)
+	}
+
+	var config Config
+	decoder := yaml.NewDecoder(bytes.NewReader(b), yaml.DisallowUnknownField())
+	if err := decoder.Decode(&config); err != nil {
+		return Config{}, fmt.Errorf("parse config: %w", err)
+	}
+
+	for _, site := range config.Sites {
+		if site.Filters == nil {
+			continue
+		}
+		if err := site.Filters.Compile(); err != nil {
+			return Config{}, fmt.Errorf("site %q: %w", site.Name, err)
+		}
+	}
+
+	return config, nil
+}
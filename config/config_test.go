@@ -0,0 +1,60 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFiltersReason(t *testing.T) {
+	f := &Filters{
+		Include: []string{`^/news/`},
+		Exclude: []string{`(?i)sponsored`},
+		MinAge:  time.Minute,
+		MaxAge:  time.Hour,
+	}
+	if err := f.Compile(); err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		title, link string
+		age         time.Duration
+		want        string
+	}{
+		{"kept", "Breaking news", "/news/a", 2 * time.Minute, ""},
+		{"excluded", "Sponsored post", "/news/a", 2 * time.Minute, "exclude"},
+		{"not included", "Breaking news", "/blog/a", 2 * time.Minute, "include"},
+		{"too young", "Breaking news", "/news/a", time.Second, "min_age"},
+		{"too old", "Breaking news", "/news/a", 2 * time.Hour, "max_age"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.Reason(tt.title, "", tt.link, tt.age); got != tt.want {
+				t.Errorf("Reason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFiltersCacheReasonIgnoresMinAge(t *testing.T) {
+	f := &Filters{MinAge: time.Hour, MaxAge: 24 * time.Hour}
+	if err := f.Compile(); err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	if got := f.CacheReason("t", "", "/a", time.Second); got != "" {
+		t.Errorf("CacheReason() = %q for a too-young item, want \"\" so it stays cached", got)
+	}
+	if got := f.Reason("t", "", "/a", time.Second); got != "min_age" {
+		t.Errorf("Reason() = %q for a too-young item, want %q", got, "min_age")
+	}
+}
+
+func TestFiltersCompileBadRegex(t *testing.T) {
+	f := &Filters{Include: []string{"("}}
+	if err := f.Compile(); err == nil {
+		t.Fatal("expected an error for an invalid regex")
+	}
+}
@@ -0,0 +1,127 @@
+//go:build chromium
+
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// ChromiumPool holds one shared headless Chromium instance and bounds how
+// many tabs may render concurrently, so many sites configured for
+// render: chromium don't spawn unbounded tabs against the same browser.
+type ChromiumPool struct {
+	tabs chan struct{}
+
+	initOnce      sync.Once
+	initErr       error
+	allocCtx      context.Context
+	allocCancel   context.CancelFunc
+	browserCtx    context.Context
+	browserCancel context.CancelFunc
+}
+
+// NewChromiumPool returns a pool allowing up to maxTabs concurrent tabs.
+// The browser itself is launched lazily, on the first Fetch.
+func NewChromiumPool(maxTabs int) *ChromiumPool {
+	if maxTabs < 1 {
+		maxTabs = 1
+	}
+	return &ChromiumPool{tabs: make(chan struct{}, maxTabs)}
+}
+
+// browser returns the context of the one shared browser, launching it on the
+// first call. Every subsequent Fetch derives its tab from this same context,
+// so renders share one browser process instead of starting a new one each.
+func (p *ChromiumPool) browser() (context.Context, error) {
+	p.initOnce.Do(func() {
+		p.allocCtx, p.allocCancel = chromedp.NewExecAllocator(context.Background(), chromedp.DefaultExecAllocatorOptions[:]...)
+		p.browserCtx, p.browserCancel = chromedp.NewContext(p.allocCtx)
+
+		// Launch now so a missing Chrome binary surfaces here, letting the
+		// caller fall back to the HTTP fetcher instead of hanging on the
+		// first real Fetch.
+		if err := chromedp.Run(p.browserCtx); err != nil {
+			p.initErr = fmt.Errorf("launch chromium: %w", err)
+		}
+	})
+	return p.browserCtx, p.initErr
+}
+
+// fetch renders url in its own tab, waiting for selector to become visible
+// (or a short grace period if selector is empty) before capturing the DOM.
+func (p *ChromiumPool) fetch(ctx context.Context, url, selector string, timeout time.Duration) (io.ReadCloser, error) {
+	browserCtx, err := p.browser()
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case p.tabs <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-p.tabs }()
+
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	tabCtx, tabCancel := chromedp.NewContext(browserCtx)
+	defer tabCancel()
+
+	// Propagate the caller's cancellation and the timeout above into the tab
+	// context, since chromedp.NewContext only inherits allocator/browser
+	// state from browserCtx, not ctx's deadline.
+	go func() {
+		<-ctx.Done()
+		tabCancel()
+	}()
+
+	wait := chromedp.Action(chromedp.Sleep(500 * time.Millisecond))
+	if selector != "" {
+		wait = chromedp.WaitVisible(selector, chromedp.ByQuery)
+	}
+
+	var html string
+	if err := chromedp.Run(tabCtx,
+		chromedp.Navigate(url),
+		wait,
+		chromedp.OuterHTML("html", &html, chromedp.ByQuery),
+	); err != nil {
+		return nil, fmt.Errorf("render %q: %w", url, err)
+	}
+
+	return io.NopCloser(strings.NewReader(html)), nil
+}
+
+// Close releases the shared browser instance. Safe to call even if no
+// Fetch ever ran.
+func (p *ChromiumPool) Close() {
+	if p.browserCancel != nil {
+		p.browserCancel()
+	}
+	if p.allocCancel != nil {
+		p.allocCancel()
+	}
+}
+
+// ChromiumFetcher adapts a ChromiumPool into the Fetcher interface for one
+// site's wait selector and timeout.
+type ChromiumFetcher struct {
+	Pool     *ChromiumPool
+	Selector string
+	Timeout  time.Duration
+}
+
+func (f *ChromiumFetcher) Fetch(ctx context.Context, url string) (io.ReadCloser, error) {
+	return f.Pool.fetch(ctx, url, f.Selector, f.Timeout)
+}
@@ -0,0 +1,32 @@
+//go:build !chromium
+
+package fetch
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// ChromiumPool stands in for the real browser pool in binaries built
+// without the chromium tag, so callers can refer to the type unconditionally.
+type ChromiumPool struct{}
+
+// NewChromiumPool returns a pool with which ChromiumFetcher.Fetch always
+// reports ErrChromiumUnavailable.
+func NewChromiumPool(maxTabs int) *ChromiumPool { return &ChromiumPool{} }
+
+// Close is a no-op in this build.
+func (p *ChromiumPool) Close() {}
+
+// ChromiumFetcher mirrors the real type's fields so call sites don't need
+// a build tag of their own.
+type ChromiumFetcher struct {
+	Pool     *ChromiumPool
+	Selector string
+	Timeout  time.Duration
+}
+
+func (f *ChromiumFetcher) Fetch(ctx context.Context, url string) (io.ReadCloser, error) {
+	return nil, ErrChromiumUnavailable
+}
@@ -0,0 +1,21 @@
+// Package fetch retrieves a site's HTML, either with a plain HTTP request
+// or, for pages that render their content client-side, through a headless
+// Chromium tab.
+package fetch
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// Fetcher retrieves the HTML document at url.
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) (io.ReadCloser, error)
+}
+
+// ErrChromiumUnavailable is returned by a ChromiumFetcher when this binary
+// was built without the chromium tag. The fetcher New returns for
+// render: chromium falls back to the HTTP fetcher silently when it sees this
+// error, rather than failing the site's update.
+var ErrChromiumUnavailable = errors.New("fetch: this binary was built without chromium support (build with -tags chromium)")
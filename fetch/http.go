@@ -0,0 +1,44 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// StatusError reports a non-200 response from an HTTPFetcher, so callers
+// can tell a failed fetch apart from a network-level error.
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("non 200 status for %q: %d", e.URL, e.StatusCode)
+}
+
+// HTTPFetcher fetches a page with a plain GET request. It is the default
+// backend and the fallback used when the chromium backend isn't available.
+type HTTPFetcher struct {
+	Client *http.Client
+}
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request for %q: %w", url, err)
+	}
+
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %q: %w", url, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, &StatusError{URL: url, StatusCode: resp.StatusCode}
+	}
+
+	return resp.Body, nil
+}
@@ -0,0 +1,76 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+
+	"github.com/sj14/site2rss/config"
+)
+
+// New returns the Fetcher configured for site: render: chromium renders
+// the page in headless Chromium first, falling back to the plain HTTP
+// fetcher if the render fails (logging a warning, except when the binary
+// was built without chromium support); anything else, including the zero
+// value, fetches the page as-is.
+func New(site config.Site, client *http.Client, pool *ChromiumPool) Fetcher {
+	httpFetcher := &timedFetcher{
+		Fetcher: &HTTPFetcher{Client: client},
+		name:    site.Name,
+		backend: "http",
+	}
+
+	if site.Render != config.RenderChromium {
+		return httpFetcher
+	}
+
+	chromiumFetcher := &timedFetcher{
+		Fetcher: &ChromiumFetcher{Pool: pool, Selector: site.RenderSelector, Timeout: site.RenderTimeout},
+		name:    site.Name,
+		backend: "chromium",
+	}
+
+	return &fallbackFetcher{name: site.Name, primary: chromiumFetcher, fallback: httpFetcher}
+}
+
+// timedFetcher wraps a Fetcher and records render_duration_seconds{name,
+// backend} around every Fetch call.
+type timedFetcher struct {
+	Fetcher
+	name, backend string
+}
+
+func (f *timedFetcher) Fetch(ctx context.Context, url string) (io.ReadCloser, error) {
+	start := time.Now()
+	rc, err := f.Fetcher.Fetch(ctx, url)
+	metrics.GetOrCreateHistogram(fmt.Sprintf(`render_duration_seconds{name=%q,backend=%q}`, f.name, f.backend)).UpdateDuration(start)
+	return rc, err
+}
+
+// fallbackFetcher tries primary first and falls back to fallback if primary
+// fails, logging a warning unless the error is ErrChromiumUnavailable. This
+// is how a site configured for
+// render: chromium keeps getting a feed on a host without Chromium
+// installed, or when rendering a particular page fails outright.
+type fallbackFetcher struct {
+	name              string
+	primary, fallback Fetcher
+}
+
+func (f *fallbackFetcher) Fetch(ctx context.Context, url string) (io.ReadCloser, error) {
+	rc, err := f.primary.Fetch(ctx, url)
+	if err == nil {
+		return rc, nil
+	}
+
+	if !errors.Is(err, ErrChromiumUnavailable) {
+		slog.Warn("chromium fetch failed, falling back to http", "site", f.name, "url", url, "err", err)
+	}
+	return f.fallback.Fetch(ctx, url)
+}
@@ -1,9 +1,10 @@
 package main
 
 import (
-	"bytes"
 	"context"
+	_ "embed"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"html"
@@ -14,42 +15,37 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"runtime/debug"
 	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/VictoriaMetrics/metrics"
-	"github.com/goccy/go-yaml"
+	"github.com/fsnotify/fsnotify"
 	"github.com/gorilla/feeds"
 	"golang.org/x/sync/errgroup"
-)
-
-type Config struct {
-	Sites []Site `yaml:"sites"`
-}
 
-type Site struct {
-	Name        string   `yaml:"name"`
-	Title       string   `yaml:"title"`
-	Description string   `yaml:"description"`
-	URL         string   `yaml:"url"`
-	Selector    Selector `yaml:"selector"`
-}
+	"github.com/sj14/site2rss/config"
+	"github.com/sj14/site2rss/fetch"
+	"github.com/sj14/site2rss/scheduler"
+)
 
-type Selector struct {
-	Item        string `yaml:"item"`
-	Link        string `yaml:"link"`
-	Title       string `yaml:"title"`
-	Description string `yaml:"description"`
-}
+//go:embed assets/feed.xsl
+var defaultStylesheet []byte
 
 type Item struct {
 	Title       string
 	Link        string
 	Description string
 	AddedAt     time.Time
+	Content     string    `json:",omitempty"`
+	Author      string    `json:",omitempty"`
+	Published   time.Time `json:",omitempty"`
 }
 
 func lookupEnvString(key string, defaultVal string) string {
@@ -70,72 +66,58 @@ func lookupEnvDuration(key string, defaultVal time.Duration) time.Duration {
 	return defaultVal
 }
 
+func lookupEnvInt(key string, defaultVal int) int {
+	if val, ok := os.LookupEnv(key); ok {
+		n, err := strconv.Atoi(val)
+		if err != nil {
+			log.Fatalf("failed parsing %q as int (%q): %v", val, key, err)
+		}
+		return n
+	}
+	return defaultVal
+}
+
 func main() {
 	var (
 		configPath     = flag.String("config", lookupEnvString("CONFIG", "config.yaml"), "path to the config file")
 		cachePath      = flag.String("cache", lookupEnvString("CACHE", "cache"), "path to the cache dir")
-		updateInterval = flag.Duration("interval", lookupEnvDuration("INTERVAL", 1*time.Hour), "update interval")
+		updateInterval = flag.Duration("interval", lookupEnvDuration("INTERVAL", 1*time.Hour), "default update interval, overridden per site by interval:")
 		addr           = flag.String("listen", lookupEnvString("LISTEN", ":8080"), "listen address")
+		chromiumTabs   = flag.Int("chromium-tabs", lookupEnvInt("CHROMIUM_TABS", 2), "max concurrent chromium tabs across all sites using render: chromium")
 	)
 	flag.Parse()
 
-	confBytes, err := os.ReadFile(*configPath)
+	cfg, err := config.Load(*configPath)
 	if err != nil {
 		log.Fatalln(err)
 	}
+	currentConfig.Store(&cfg)
 
-	var config Config
-	decoder := yaml.NewDecoder(bytes.NewReader(confBytes), yaml.DisallowUnknownField())
-
-	err = decoder.Decode(&config)
-	if err != nil {
-		log.Fatalln(err)
+	registerBuildInfo()
+	for _, site := range cfg.Sites {
+		registerSiteMetrics(site.Name)
 	}
 
-	go func() {
-		for {
-			updates := make(map[string]uint64, len(config.Sites))
-
-			for _, site := range config.Sites {
-				count, err := updateCache(site, *cachePath)
-				if err != nil {
-					log.Println(err)
-					// do not continue the loop to update the metrics below
-				}
-
-				updates[site.Name] = count
-				if itemSizesMetrics[site.Name] == nil {
-					itemSizesMetrics[site.Name] = metrics.NewGauge(fmt.Sprintf(`item_size{name="%s"}`, site.Name), nil)
-				}
-				itemSizesMetrics[site.Name].Set(float64(count))
-			}
-
-			for site, updated := range updates {
-				slog.Info("updates", site, updated)
-			}
+	chromiumPool := fetch.NewChromiumPool(*chromiumTabs)
+	defer chromiumPool.Close()
 
-			time.Sleep(*updateInterval)
-		}
-	}()
+	sched := scheduler.New(*updateInterval, func(ctx context.Context, site config.Site) (uint64, error) {
+		return updateCache(ctx, site, *cachePath, currentConfig.Load().Stylesheet, chromiumPool)
+	}, onUpdate)
 
-	for _, site := range config.Sites {
-		http.HandleFunc("/"+strings.ToLower(site.Name)+"/rss", func(w http.ResponseWriter, r *http.Request) {
-			w.Write([]byte(state[strings.ToLower(site.Name)+"_rss"]))
-		})
-		http.HandleFunc("/"+strings.ToLower(site.Name)+"/atom", func(w http.ResponseWriter, r *http.Request) {
-			w.Write([]byte(state[strings.ToLower(site.Name)+"_atom"]))
-		})
-		http.HandleFunc("/"+strings.ToLower(site.Name)+"/json", func(w http.ResponseWriter, r *http.Request) {
-			w.Write([]byte(state[strings.ToLower(site.Name)+"_json"]))
-		})
+	for _, site := range cfg.Sites {
+		sched.AddSite(site)
 	}
 
-	http.HandleFunc("/metrics", func(w http.ResponseWriter, req *http.Request) {
-		metrics.WritePrometheus(w, true)
-	})
+	mux.Store(buildMux(cfg.Sites))
+
+	go watchConfig(*configPath, sched)
 
 	srv := http.Server{
 		Addr: *addr,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mux.Load().ServeHTTP(w, r)
+		}),
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -160,6 +142,7 @@ func main() {
 		slog.Info("shutting down")
 		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 		defer cancel()
+		sched.Stop()
 		return srv.Shutdown(ctx)
 	})
 
@@ -171,11 +154,205 @@ func main() {
 }
 
 var (
-	itemSizesMetrics = map[string]*metrics.Gauge{}
-	state            = map[string]string{}
+	itemSizesMetrics   = map[string]*metrics.Gauge{}
+	itemSizesMetricsMu sync.Mutex
+	state              = map[string]string{}
+	stateMu            sync.Mutex
+
+	currentConfig atomic.Pointer[config.Config]
+	mux           atomic.Pointer[http.ServeMux]
 )
 
-func updateCache(site Site, cachePath string) (uint64, error) {
+// fetchStatuses are the values fetch_total{name,status} is pre-registered
+// with, so the counter series exist on /metrics even at zero.
+var fetchStatuses = []string{"ok", "http_error", "parse_error", "network_error"}
+
+// registerSiteMetrics pre-creates the fetch_duration_seconds, fetch_total,
+// last_success_timestamp_seconds and items_new_total series for name, so
+// /metrics is stable even before the site's first scrape.
+// items_filtered_total is not pre-created; it appears once an item is
+// filtered.
+func registerSiteMetrics(name string) {
+	metrics.GetOrCreateHistogram(fmt.Sprintf(`fetch_duration_seconds{name=%q}`, name))
+	for _, status := range fetchStatuses {
+		metrics.GetOrCreateCounter(fmt.Sprintf(`fetch_total{name=%q,status=%q}`, name, status))
+	}
+	metrics.GetOrCreateGauge(fmt.Sprintf(`last_success_timestamp_seconds{name=%q}`, name), nil)
+	metrics.GetOrCreateCounter(fmt.Sprintf(`items_new_total{name=%q}`, name))
+}
+
+// unregisterSiteMetrics removes every metric series registerSiteMetrics
+// created for name, mirroring the item_size cleanup already done for
+// removed sites.
+func unregisterSiteMetrics(name string) {
+	metrics.UnregisterMetric(fmt.Sprintf(`fetch_duration_seconds{name=%q}`, name))
+	for _, status := range fetchStatuses {
+		metrics.UnregisterMetric(fmt.Sprintf(`fetch_total{name=%q,status=%q}`, name, status))
+	}
+	metrics.UnregisterMetric(fmt.Sprintf(`last_success_timestamp_seconds{name=%q}`, name))
+	metrics.UnregisterMetric(fmt.Sprintf(`items_new_total{name=%q}`, name))
+}
+
+// registerBuildInfo publishes a one-shot build_info gauge set to 1, labeled
+// with the module version, VCS revision and Go toolchain used to produce
+// the binary, so dashboards can tell which build a fleet is running.
+func registerBuildInfo() {
+	version, commit, goVersion := "unknown", "unknown", "unknown"
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		goVersion = bi.GoVersion
+		if bi.Main.Version != "" {
+			version = bi.Main.Version
+		}
+		for _, setting := range bi.Settings {
+			if setting.Key == "vcs.revision" {
+				commit = setting.Value
+			}
+		}
+	}
+
+	metrics.GetOrCreateGauge(fmt.Sprintf(`build_info{version=%q,commit=%q,go_version=%q}`, version, commit, goVersion), nil).Set(1)
+}
+
+// onUpdate is the scheduler's per-site result callback: it logs the
+// outcome and keeps the item_size gauge for site up to date.
+func onUpdate(site config.Site, count uint64, err error) {
+	if err != nil {
+		log.Println(err)
+		// still fall through so the gauge is updated; a failed update reports 0
+	}
+
+	slog.Info("updates", site.Name, count)
+
+	itemSizesMetricsMu.Lock()
+	gauge, ok := itemSizesMetrics[site.Name]
+	if !ok {
+		gauge = metrics.NewGauge(fmt.Sprintf(`item_size{name="%s"}`, site.Name), nil)
+		itemSizesMetrics[site.Name] = gauge
+	}
+	itemSizesMetricsMu.Unlock()
+
+	gauge.Set(float64(count))
+}
+
+// buildMux registers the per-site and shared HTTP handlers on a fresh
+// ServeMux, so it can be swapped atomically whenever the set of sites
+// changes.
+func buildMux(sites []config.Site) *http.ServeMux {
+	m := http.NewServeMux()
+
+	for _, site := range sites {
+		name := strings.ToLower(site.Name)
+
+		m.HandleFunc("/"+name+"/rss", func(w http.ResponseWriter, r *http.Request) {
+			stateMu.Lock()
+			defer stateMu.Unlock()
+			w.Write([]byte(state[name+"_rss"]))
+		})
+		m.HandleFunc("/"+name+"/atom", func(w http.ResponseWriter, r *http.Request) {
+			stateMu.Lock()
+			defer stateMu.Unlock()
+			w.Write([]byte(state[name+"_atom"]))
+		})
+		m.HandleFunc("/"+name+"/json", func(w http.ResponseWriter, r *http.Request) {
+			stateMu.Lock()
+			defer stateMu.Unlock()
+			w.Write([]byte(state[name+"_json"]))
+		})
+	}
+
+	m.HandleFunc("/assets/feed.xsl", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xsl; charset=utf-8")
+		w.Write(defaultStylesheet)
+	})
+
+	m.HandleFunc("/metrics", func(w http.ResponseWriter, req *http.Request) {
+		metrics.WritePrometheus(w, true)
+	})
+
+	return m
+}
+
+// watchConfig watches configPath for writes and, on change, reparses the
+// YAML and reconciles the running scheduler jobs and HTTP handlers against
+// it, without restarting the server or losing cache state.
+func watchConfig(configPath string, sched *scheduler.Scheduler) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		slog.Error("config watcher", "err", err)
+		return
+	}
+	defer watcher.Close()
+
+	// Watch the containing directory rather than the file itself: editors
+	// commonly replace the file on save, which some platforms report as
+	// the watched file being removed rather than written.
+	if err := watcher.Add(filepath.Dir(configPath)); err != nil {
+		slog.Error("config watcher", "err", err)
+		return
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(configPath) {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+
+			reloadConfig(configPath, sched)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			slog.Error("config watcher", "err", err)
+		}
+	}
+}
+
+func reloadConfig(configPath string, sched *scheduler.Scheduler) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		slog.Error("reload config", "err", err)
+		return
+	}
+
+	added, removed := sched.Reload(cfg.Sites)
+
+	stateMu.Lock()
+	itemSizesMetricsMu.Lock()
+	for _, name := range removed {
+		lower := strings.ToLower(name)
+		delete(state, lower+"_rss")
+		delete(state, lower+"_atom")
+		delete(state, lower+"_json")
+
+		if _, ok := itemSizesMetrics[name]; ok {
+			metrics.UnregisterMetric(fmt.Sprintf(`item_size{name="%s"}`, name))
+			delete(itemSizesMetrics, name)
+		}
+		unregisterSiteMetrics(name)
+	}
+	itemSizesMetricsMu.Unlock()
+	stateMu.Unlock()
+
+	for _, name := range added {
+		registerSiteMetrics(name)
+	}
+
+	currentConfig.Store(&cfg)
+	mux.Store(buildMux(cfg.Sites))
+
+	slog.Info("config reloaded", "added", added, "removed", removed)
+}
+
+func updateCache(ctx context.Context, site config.Site, cachePath string, stylesheet string, chromiumPool *fetch.ChromiumPool) (uint64, error) {
 	client := http.Client{Timeout: 10 * time.Second}
 
 	siteURL, err := url.Parse(site.URL)
@@ -183,21 +360,30 @@ func updateCache(site Site, cachePath string) (uint64, error) {
 		return 0, fmt.Errorf("failed to parse URL %q: %w", site.URL, err)
 	}
 
-	resp, err := client.Get(site.URL)
+	fetcher := fetch.New(site, &client, chromiumPool)
+
+	fetchStart := time.Now()
+	body, err := fetcher.Fetch(ctx, site.URL)
+	metrics.GetOrCreateHistogram(fmt.Sprintf(`fetch_duration_seconds{name=%q}`, site.Name)).UpdateDuration(fetchStart)
 	if err != nil {
+		status := "network_error"
+		var statusErr *fetch.StatusError
+		if errors.As(err, &statusErr) {
+			status = "http_error"
+		}
+		metrics.GetOrCreateCounter(fmt.Sprintf(`fetch_total{name=%q,status=%q}`, site.Name, status)).Inc()
 		return 0, fmt.Errorf("failed loading site (%q): %w", site.URL, err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("non 200 status for %q", site.URL)
-	}
+	defer body.Close()
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	doc, err := goquery.NewDocumentFromReader(body)
 	if err != nil {
+		metrics.GetOrCreateCounter(fmt.Sprintf(`fetch_total{name=%q,status="parse_error"}`, site.Name)).Inc()
 		return 0, fmt.Errorf("parse document: %w", err)
 	}
 
+	metrics.GetOrCreateCounter(fmt.Sprintf(`fetch_total{name=%q,status="ok"}`, site.Name)).Inc()
+
 	var items []Item
 
 	doc.Find(site.Selector.Item).Each(func(i int, s *goquery.Selection) {
@@ -247,6 +433,11 @@ func updateCache(site Site, cachePath string) (uint64, error) {
 		}
 	}
 
+	oldLinks := make(map[string]struct{}, len(oldEntries))
+	for _, old := range oldEntries {
+		oldLinks[old.Link] = struct{}{}
+	}
+
 	for newIdx, new := range items {
 		for _, old := range oldEntries {
 			if old.Title == new.Title && old.Link == new.Link && old.Description == new.Description {
@@ -255,6 +446,35 @@ func updateCache(site Site, cachePath string) (uint64, error) {
 		}
 	}
 
+	if site.Filters != nil {
+		items = filterItemsForCache(site, items)
+	}
+
+	var newCount int
+	for _, item := range items {
+		if _, ok := oldLinks[item.Link]; !ok {
+			newCount++
+		}
+	}
+	metrics.GetOrCreateCounter(fmt.Sprintf(`items_new_total{name=%q}`, site.Name)).Add(newCount)
+
+	if site.Enrich != nil {
+		oldByLink := make(map[string]Item, len(oldEntries))
+		for _, old := range oldEntries {
+			oldByLink[old.Link] = old
+		}
+
+		for i, item := range items {
+			if old, ok := oldByLink[item.Link]; ok && old.Content != "" {
+				items[i].Content = old.Content
+				items[i].Author = old.Author
+				items[i].Published = old.Published
+			}
+		}
+
+		enrichItems(ctx, &client, site, items)
+	}
+
 	slices.SortStableFunc(items, func(a, b Item) int {
 		if a.AddedAt.Equal(b.AddedAt) {
 			return 0
@@ -281,19 +501,44 @@ func updateCache(site Site, cachePath string) (uint64, error) {
 		log.Fatal(err)
 	}
 
+	feedItems := items
+	if site.Filters != nil {
+		feedItems = filterItemsForFeed(site, items)
+	}
+
 	feed := &feeds.Feed{
 		Title:       site.Title,
 		Link:        &feeds.Link{Href: site.URL},
 		Description: site.Description,
 	}
 
-	for _, lt := range items {
+	for _, lt := range feedItems {
+		created := lt.AddedAt
+		if !lt.Published.IsZero() {
+			created = lt.Published
+		}
+
+		var author *feeds.Author
+		if lt.Author != "" {
+			author = &feeds.Author{Name: lt.Author}
+		}
+
+		id := lt.Link
+		isPermaLink := ""
+		if tag, ok := tagURI(site, lt); ok {
+			id = tag
+			isPermaLink = "false"
+		}
+
 		feed.Items = append(feed.Items, &feeds.Item{
-			Id:          lt.Link,
+			Id:          id,
+			IsPermaLink: isPermaLink,
 			Title:       lt.Title,
 			Link:        &feeds.Link{Href: lt.Link},
 			Description: lt.Description,
-			Created:     lt.AddedAt,
+			Content:     lt.Content,
+			Author:      author,
+			Created:     created,
 		})
 	}
 
@@ -302,23 +547,116 @@ func updateCache(site Site, cachePath string) (uint64, error) {
 		log.Fatal(err)
 	}
 
-	state[strings.ToLower(site.Name)+"_rss"] = rss
-
 	atom, err := feed.ToAtom()
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	state[strings.ToLower(site.Name)+"_atom"] = atom
-
-	json, err := feed.ToJSON()
+	jsonFeed, err := feed.ToJSON()
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	state[strings.ToLower(site.Name)+"_json"] = json
+	name := strings.ToLower(site.Name)
+
+	stateMu.Lock()
+	state[name+"_rss"] = withStylesheet(rss, stylesheet)
+	state[name+"_atom"] = withStylesheet(atom, stylesheet)
+	state[name+"_json"] = jsonFeed
+	stateMu.Unlock()
+
+	metrics.GetOrCreateGauge(fmt.Sprintf(`last_success_timestamp_seconds{name=%q}`, site.Name), nil).Set(float64(time.Now().Unix()))
+
+	return uint64(len(feedItems)), nil
+}
+
+// withStylesheet prepends an xml-stylesheet processing instruction right
+// after the XML declaration so browsers render the feed instead of the raw
+// XML. doc is returned unchanged if href is empty or doc has no "?>".
+func withStylesheet(doc, href string) string {
+	if href == "" {
+		return doc
+	}
+
+	idx := strings.Index(doc, "?>")
+	if idx == -1 {
+		return doc
+	}
+	idx += len("?>")
 
-	return uint64(len(items)), nil
+	pi := fmt.Sprintf(`<?xml-stylesheet type="text/xsl" href=%q?>`, href)
+	return doc[:idx] + pi + doc[idx:]
+}
+
+// filterItemsForCache drops items that fail site.Filters' exclude, include
+// or max_age checks, incrementing items_filtered_total{name,reason} for each
+// one dropped. Items that only fail min_age are kept, so they can still age
+// into the feed on a later run; see config.Filters.CacheReason.
+func filterItemsForCache(site config.Site, items []Item) []Item {
+	kept := items[:0]
+
+	for _, item := range items {
+		reason := site.Filters.CacheReason(item.Title, item.Description, item.Link, time.Since(item.AddedAt))
+		if reason != "" {
+			metrics.GetOrCreateCounter(fmt.Sprintf(`items_filtered_total{name=%q,reason=%q}`, site.Name, reason)).Inc()
+			continue
+		}
+		kept = append(kept, item)
+	}
+
+	return kept
+}
+
+// filterItemsForFeed drops cached items that fail site.Filters (including
+// min_age) from the feed, incrementing items_filtered_total{name,reason} for
+// each one held back. It leaves items untouched, so held-back items stay
+// cached for a later run.
+func filterItemsForFeed(site config.Site, items []Item) []Item {
+	var kept []Item
+
+	for _, item := range items {
+		reason := site.Filters.Reason(item.Title, item.Description, item.Link, time.Since(item.AddedAt))
+		if reason != "" {
+			metrics.GetOrCreateCounter(fmt.Sprintf(`items_filtered_total{name=%q,reason=%q}`, site.Name, reason)).Inc()
+			continue
+		}
+		kept = append(kept, item)
+	}
+
+	return kept
+}
+
+// tagURI builds a stable tag: URI (RFC 4151) for item, so feed readers keep
+// deduping entries even if Link changes. It reports false if the site isn't
+// configured for tag ids or no slug could be derived from the item's Link.
+func tagURI(site config.Site, item Item) (string, bool) {
+	if site.TagDomain == "" || site.TagStartDate == "" {
+		return "", false
+	}
+
+	slug := slugify(item.Link)
+	if slug == "" {
+		return "", false
+	}
+
+	return fmt.Sprintf("tag:%s,%s:%s/%s", site.TagDomain, site.TagStartDate, strings.ToLower(site.Name), slug), true
+}
+
+// slugify returns the last path segment of link, e.g.
+// "https://example.com/news/my-article" -> "my-article".
+func slugify(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+
+	path := strings.Trim(u.Path, "/")
+	if path == "" {
+		return ""
+	}
+
+	parts := strings.Split(path, "/")
+	return parts[len(parts)-1]
 }
 
 func getField(s *goquery.Selection, selector string) string {
@@ -330,3 +668,175 @@ func getField(s *goquery.Selection, selector string) string {
 	}
 	return el.Text()
 }
+
+// enrichItems fetches each item's Link in a bounded worker pool and fills in
+// Content, Author and Published from the detail page. Items are modified
+// in place; a fetch or parse error for one item is logged and skipped, it
+// never drops the item from items.
+func enrichItems(ctx context.Context, client *http.Client, site config.Site, items []Item) {
+	concurrency := site.Enrich.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	limiter := newHostLimiter(site.Enrich.RateLimit)
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+
+	for i := range items {
+		item := &items[i]
+
+		if item.Content != "" {
+			continue // already enriched, reuse what the cache carried forward
+		}
+		if site.Enrich.MaxDepth > 0 && i >= site.Enrich.MaxDepth {
+			continue
+		}
+
+		g.Go(func() error {
+			if err := enrichItem(ctx, client, site, limiter, item); err != nil {
+				slog.Warn("enrich item", "site", site.Name, "link", item.Link, "err", err)
+			}
+			return nil // a single item's enrichment failure must not abort the pool
+		})
+	}
+
+	_ = g.Wait()
+}
+
+func enrichItem(ctx context.Context, client *http.Client, site config.Site, limiter *hostLimiter, item *Item) error {
+	itemURL, err := url.Parse(item.Link)
+	if err != nil {
+		return fmt.Errorf("parse item link %q: %w", item.Link, err)
+	}
+
+	limiter.wait(itemURL.Host)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, item.Link, nil)
+	if err != nil {
+		return fmt.Errorf("build request for %q: %w", item.Link, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch %q: %w", item.Link, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("non 200 status for %q: %d", item.Link, resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return fmt.Errorf("parse document %q: %w", item.Link, err)
+	}
+
+	if sel := site.Enrich.ContentSelector; sel != "" {
+		content, err := getFieldHTML(doc.Selection, sel, itemURL)
+		if err != nil {
+			return fmt.Errorf("extract content from %q: %w", item.Link, err)
+		}
+		item.Content = content
+	}
+
+	if sel := site.Enrich.AuthorSelector; sel != "" {
+		item.Author = strings.TrimSpace(html.UnescapeString(getField(doc.Selection, sel)))
+	}
+
+	if sel := site.Enrich.PublishedSelector; sel != "" {
+		if published, ok := parsePublished(getField(doc.Selection, sel)); ok {
+			item.Published = published
+		}
+	}
+
+	return nil
+}
+
+// getFieldHTML returns the inner HTML of selector, resolving any relative
+// href/src attributes against base so the content still makes sense once
+// it has been copied out of the page it came from.
+func getFieldHTML(s *goquery.Selection, selector string, base *url.URL) (string, error) {
+	sel := s.Find(selector)
+
+	sel.Find("a[href]").Each(func(_ int, a *goquery.Selection) {
+		if href, ok := a.Attr("href"); ok {
+			if resolved, err := resolveURL(base, href); err == nil {
+				a.SetAttr("href", resolved)
+			}
+		}
+	})
+
+	sel.Find("img[src]").Each(func(_ int, img *goquery.Selection) {
+		if src, ok := img.Attr("src"); ok {
+			if resolved, err := resolveURL(base, src); err == nil {
+				img.SetAttr("src", resolved)
+			}
+		}
+	})
+
+	return sel.Html()
+}
+
+func resolveURL(base *url.URL, ref string) (string, error) {
+	refURL, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return "", fmt.Errorf("parse %q: %w", ref, err)
+	}
+	return base.ResolveReference(refURL).String(), nil
+}
+
+var publishedLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+func parsePublished(raw string) (time.Time, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, false
+	}
+
+	for _, layout := range publishedLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// hostLimiter enforces a minimum delay between requests to the same host.
+type hostLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     map[string]time.Time
+}
+
+func newHostLimiter(interval time.Duration) *hostLimiter {
+	return &hostLimiter{interval: interval, last: map[string]time.Time{}}
+}
+
+func (h *hostLimiter) wait(host string) {
+	if h.interval <= 0 {
+		return
+	}
+
+	h.mu.Lock()
+	var wait time.Duration
+	if last, ok := h.last[host]; ok {
+		wait = h.interval - time.Since(last)
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	h.last[host] = time.Now().Add(wait)
+	h.mu.Unlock()
+
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/sj14/site2rss/config"
+)
+
+func TestResolveURL(t *testing.T) {
+	base, err := url.Parse("https://example.com/news/article-1")
+	if err != nil {
+		t.Fatalf("parse base: %v", err)
+	}
+
+	tests := map[string]string{
+		"/img/photo.jpg":            "https://example.com/img/photo.jpg",
+		"other-article":             "https://example.com/news/other-article",
+		"https://cdn.example.com/x": "https://cdn.example.com/x",
+	}
+
+	for ref, want := range tests {
+		got, err := resolveURL(base, ref)
+		if err != nil {
+			t.Fatalf("resolveURL(%q): %v", ref, err)
+		}
+		if got != want {
+			t.Errorf("resolveURL(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
+
+func TestEnrichItemsPartialFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			w.Write([]byte(`<html><body><div class="content">hello <a href="/more">more</a></div><span class="author">Jane</span></body></html>`))
+		case "/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	site := config.Site{
+		Name: "test",
+		Enrich: &config.Enrich{
+			ContentSelector: "div.content",
+			AuthorSelector:  "span.author",
+		},
+	}
+
+	items := []Item{
+		{Title: "ok", Link: srv.URL + "/ok"},
+		{Title: "broken", Link: srv.URL + "/broken"},
+	}
+
+	enrichItems(context.Background(), &http.Client{}, site, items)
+
+	if items[0].Content == "" || items[0].Author != "Jane" {
+		t.Errorf("expected item 0 to be enriched, got %+v", items[0])
+	}
+	if items[1].Content != "" {
+		t.Errorf("expected item 1 enrichment to fail without content, got %q", items[1].Content)
+	}
+}
+
+func TestCacheMigrationOldFormat(t *testing.T) {
+	old := `[{"Title":"t","Link":"https://example.com/a","Description":"d","AddedAt":"2024-01-01T00:00:00Z"}]`
+
+	var items []Item
+	if err := json.Unmarshal([]byte(old), &items); err != nil {
+		t.Fatalf("unmarshal old cache format: %v", err)
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(items))
+	}
+	if items[0].Content != "" || items[0].Author != "" || !items[0].Published.IsZero() {
+		t.Errorf("expected zero-valued enrichment fields for old cache entry, got %+v", items[0])
+	}
+	if !items[0].AddedAt.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected AddedAt: %v", items[0].AddedAt)
+	}
+}
+
+func TestWithStylesheet(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="UTF-8"?><rss></rss>`
+
+	if got := withStylesheet(doc, ""); got != doc {
+		t.Errorf("expected doc unchanged when href is empty, got %q", got)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?><?xml-stylesheet type="text/xsl" href="/assets/feed.xsl"?><rss></rss>`
+	if got := withStylesheet(doc, "/assets/feed.xsl"); got != want {
+		t.Errorf("withStylesheet() = %q, want %q", got, want)
+	}
+}
+
+func TestRegisterSiteMetrics(t *testing.T) {
+	defer unregisterSiteMetrics("metricstest")
+
+	registerSiteMetrics("metricstest")
+	registerBuildInfo()
+	// An empty Histogram prints no series at all, so observe one sample to
+	// confirm fetch_duration_seconds was registered under the right name.
+	metrics.GetOrCreateHistogram(`fetch_duration_seconds{name="metricstest"}`).UpdateDuration(time.Now())
+
+	var buf bytes.Buffer
+	metrics.WritePrometheus(&buf, true)
+	out := buf.String()
+
+	for _, want := range []string{
+		`fetch_duration_seconds_count{name="metricstest"}`,
+		`fetch_total{name="metricstest",status="ok"}`,
+		`fetch_total{name="metricstest",status="http_error"}`,
+		`fetch_total{name="metricstest",status="parse_error"}`,
+		`fetch_total{name="metricstest",status="network_error"}`,
+		`last_success_timestamp_seconds{name="metricstest"}`,
+		`items_new_total{name="metricstest"}`,
+		`build_info{`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected /metrics output to contain %q", want)
+		}
+	}
+}
+
+func TestFilterItemsForCache(t *testing.T) {
+	site := config.Site{
+		Name: "test",
+		Filters: &config.Filters{
+			Exclude: []string{"(?i)sponsored"},
+		},
+	}
+	if err := site.Filters.Compile(); err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	items := []Item{
+		{Title: "Breaking news", AddedAt: time.Now()},
+		{Title: "Sponsored post", AddedAt: time.Now()},
+	}
+
+	got := filterItemsForCache(site, items)
+	if len(got) != 1 || got[0].Title != "Breaking news" {
+		t.Errorf("filterItemsForCache() = %+v, want only the non-sponsored item", got)
+	}
+}
+
+func TestFilterItemsForCacheKeepsTooYoungItems(t *testing.T) {
+	site := config.Site{
+		Name: "test",
+		Filters: &config.Filters{
+			MinAge: time.Hour,
+		},
+	}
+	if err := site.Filters.Compile(); err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	items := []Item{{Title: "Just scraped", AddedAt: time.Now()}}
+
+	got := filterItemsForCache(site, items)
+	if len(got) != 1 {
+		t.Fatalf("filterItemsForCache() dropped a too-young item, want it kept so it can age in; got %+v", got)
+	}
+
+	feed := filterItemsForFeed(site, got)
+	if len(feed) != 0 {
+		t.Errorf("filterItemsForFeed() = %+v, want the too-young item held back", feed)
+	}
+}
+
+func TestTagURI(t *testing.T) {
+	site := config.Site{Name: "News", TagDomain: "example.com", TagStartDate: "2024-01-01"}
+	item := Item{Link: "https://example.com/news/my-article"}
+
+	got, ok := tagURI(site, item)
+	if !ok {
+		t.Fatalf("expected a tag URI to be built")
+	}
+
+	want := "tag:example.com,2024-01-01:news/my-article"
+	if got != want {
+		t.Errorf("tagURI() = %q, want %q", got, want)
+	}
+
+	if _, ok := tagURI(config.Site{Name: "News"}, item); ok {
+		t.Errorf("expected no tag URI without tag_domain/tag_start_date")
+	}
+}
@@ -0,0 +1,184 @@
+// Package scheduler runs one update job per site, each on its own ticker,
+// and lets the running set of jobs be changed at runtime without losing the
+// state of jobs that keep running.
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sj14/site2rss/config"
+)
+
+// UpdateFunc performs one update of site and reports how many items it
+// produced. ctx is cancelled when the site's job is stopped or the
+// Scheduler shuts down, so a long-running fetch (e.g. a headless browser
+// render) doesn't outlive either.
+type UpdateFunc func(ctx context.Context, site config.Site) (uint64, error)
+
+// ResultFunc is called after every update, successful or not.
+type ResultFunc func(site config.Site, count uint64, err error)
+
+// Scheduler runs a goroutine per site, ticking on the site's own Interval
+// (falling back to DefaultInterval), and exposes AddSite/RemoveSite/Reload
+// so the running set can be changed without restarting the process.
+type Scheduler struct {
+	DefaultInterval time.Duration
+
+	update ResultFunc
+	run    UpdateFunc
+
+	mu   sync.Mutex
+	jobs map[string]*job
+}
+
+type job struct {
+	site   config.Site
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New creates a Scheduler. run performs a single site update; onResult (may
+// be nil) is called after every run with its outcome.
+func New(defaultInterval time.Duration, run UpdateFunc, onResult ResultFunc) *Scheduler {
+	return &Scheduler{
+		DefaultInterval: defaultInterval,
+		run:             run,
+		update:          onResult,
+		jobs:            map[string]*job{},
+	}
+}
+
+// AddSite starts a ticking job for site. It is a no-op if a job for
+// site.Name is already running.
+func (s *Scheduler) AddSite(site config.Site) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[site.Name]; ok {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	j := &job{site: site, cancel: cancel, done: make(chan struct{})}
+	s.jobs[site.Name] = j
+
+	go s.runJob(ctx, j)
+}
+
+func (s *Scheduler) runJob(ctx context.Context, j *job) {
+	defer close(j.done)
+
+	interval := j.site.Interval
+	if interval <= 0 {
+		interval = s.DefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	s.tick(ctx, j)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.tick(ctx, j)
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context, j *job) {
+	count, err := s.run(ctx, j.site)
+	if s.update != nil {
+		s.update(j.site, count, err)
+	}
+}
+
+// RemoveSite stops the job for name, if any, and waits for it to exit.
+func (s *Scheduler) RemoveSite(name string) {
+	s.mu.Lock()
+	j, ok := s.jobs[name]
+	if ok {
+		delete(s.jobs, name)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	j.cancel()
+	<-j.done
+}
+
+// Reload diffs sites against the running set: jobs for sites no longer
+// present are stopped, jobs for new sites are started, and jobs whose site
+// config changed are restarted. It returns the names added and removed;
+// restarted sites are reported in added.
+func (s *Scheduler) Reload(sites []config.Site) (added, removed []string) {
+	wanted := make(map[string]config.Site, len(sites))
+	for _, site := range sites {
+		wanted[site.Name] = site
+	}
+
+	s.mu.Lock()
+	var stale []string
+	for name := range s.jobs {
+		if _, ok := wanted[name]; !ok {
+			stale = append(stale, name)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, name := range stale {
+		s.RemoveSite(name)
+		removed = append(removed, name)
+	}
+
+	for _, site := range sites {
+		s.mu.Lock()
+		j, ok := s.jobs[site.Name]
+		s.mu.Unlock()
+
+		switch {
+		case !ok:
+			s.AddSite(site)
+			added = append(added, site.Name)
+		case !j.site.Equal(site):
+			s.RemoveSite(site.Name)
+			s.AddSite(site)
+			added = append(added, site.Name)
+		}
+	}
+
+	return added, removed
+}
+
+// Sites returns the names of the currently running jobs.
+func (s *Scheduler) Sites() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Stop stops every running job and waits for them all to exit.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	s.mu.Unlock()
+
+	for _, name := range names {
+		s.RemoveSite(name)
+	}
+}
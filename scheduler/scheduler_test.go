@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sj14/site2rss/config"
+)
+
+func TestSchedulerRunsEachSiteOnItsOwnInterval(t *testing.T) {
+	var runs atomic.Int32
+
+	s := New(time.Hour, func(ctx context.Context, site config.Site) (uint64, error) {
+		runs.Add(1)
+		return 1, nil
+	}, nil)
+
+	s.AddSite(config.Site{Name: "fast", Interval: 5 * time.Millisecond})
+	defer s.Stop()
+
+	time.Sleep(50 * time.Millisecond)
+
+	if got := runs.Load(); got < 3 {
+		t.Errorf("expected at least 3 runs for a 5ms interval over 50ms, got %d", got)
+	}
+}
+
+func TestSchedulerReload(t *testing.T) {
+	s := New(time.Hour, func(ctx context.Context, site config.Site) (uint64, error) { return 0, nil }, nil)
+
+	added, removed := s.Reload([]config.Site{{Name: "a"}, {Name: "b"}})
+	if len(added) != 2 || len(removed) != 0 {
+		t.Fatalf("first reload: added=%v removed=%v", added, removed)
+	}
+
+	added, removed = s.Reload([]config.Site{{Name: "a"}})
+	if len(added) != 0 || len(removed) != 1 || removed[0] != "b" {
+		t.Fatalf("second reload: added=%v removed=%v", added, removed)
+	}
+
+	if got := s.Sites(); len(got) != 1 || got[0] != "a" {
+		t.Errorf("expected only site a running, got %v", got)
+	}
+
+	s.Stop()
+	if got := s.Sites(); len(got) != 0 {
+		t.Errorf("expected no sites running after Stop, got %v", got)
+	}
+}
+
+func TestSchedulerReloadSkipsUnchangedSiteWithPointerFields(t *testing.T) {
+	s := New(time.Hour, func(ctx context.Context, site config.Site) (uint64, error) { return 0, nil }, nil)
+	defer s.Stop()
+
+	site := config.Site{
+		Name:    "a",
+		Filters: &config.Filters{Exclude: []string{"ad"}},
+		Enrich:  &config.Enrich{ContentSelector: ".body"},
+	}
+
+	added, removed := s.Reload([]config.Site{site})
+	if len(added) != 1 || len(removed) != 0 {
+		t.Fatalf("first reload: added=%v removed=%v", added, removed)
+	}
+
+	// A config reload reparses the YAML into freshly-allocated Filters/Enrich
+	// pointers even when nothing changed; the job should not be restarted.
+	reparsed := site
+	reparsed.Filters = &config.Filters{Exclude: []string{"ad"}}
+	reparsed.Enrich = &config.Enrich{ContentSelector: ".body"}
+
+	added, removed = s.Reload([]config.Site{reparsed})
+	if len(added) != 0 || len(removed) != 0 {
+		t.Errorf("reload with equal-but-distinct pointers restarted the job: added=%v removed=%v", added, removed)
+	}
+}